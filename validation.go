@@ -0,0 +1,529 @@
+// Package envconfig provides struct tag driven configuration loading and
+// validation.
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ValidationError describes a single failed validation rule. Tag and Param
+// identify the rule that failed (e.g. Tag "min", Param "3"); Message, when
+// set explicitly, is used as-is by Error, otherwise Error derives the text
+// from Tag/Param via a Translator. ValidationErrors.Translate always derives
+// the text from Tag/Param and ignores Message.
+type ValidationError struct {
+	// Field is the dotted namespace of the offending field, e.g. "parent.child".
+	Field   string
+	Message string
+	// Tag is the name of the rule that failed, e.g. "min" or "email".
+	Tag string
+	// Param is the parameter the rule was given, e.g. "3" in "min=3".
+	Param string
+}
+
+// Error implements the error interface. If Message was set explicitly it is
+// used verbatim; otherwise the message is derived from Tag/Param using
+// DefaultTranslator.
+func (e ValidationError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = DefaultTranslator.Translate(e)
+	}
+
+	return fmt.Sprintf("validation error for field '%s': %s", e.Field, msg)
+}
+
+// ValidationErrors is a collection of ValidationError, returned by
+// ValidateStruct when one or more rules fail.
+type ValidationErrors []ValidationError
+
+// Error implements the error interface, joining every failure into a single
+// message.
+func (e ValidationErrors) Error() string {
+	if len(e) == 0 {
+		return "no validation errors"
+	}
+
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return fmt.Sprintf("validation failed with %d error(s): %s", len(e), strings.Join(messages, "; "))
+}
+
+// Translate renders every error in e using t, keyed by each error's Field
+// namespace. If two errors share a namespace, the later one wins.
+func (e ValidationErrors) Translate(t Translator) map[string]string {
+	messages := make(map[string]string, len(e))
+
+	for _, ve := range e {
+		messages[ve.Field] = t.Translate(ve)
+	}
+
+	return messages
+}
+
+// Validator validates a configuration struct after it has been populated.
+type Validator interface {
+	ValidateStruct(config any) error
+}
+
+// ValidationFunc is a single validation rule. It receives the field under
+// validation via fl and reports whether the field satisfies the rule.
+type ValidationFunc func(fl FieldLevel) bool
+
+// FieldLevel gives a ValidationFunc access to the field currently being
+// validated along with the parameter that was passed to the rule.
+type FieldLevel interface {
+	// Field is the reflect.Value of the field under validation.
+	Field() reflect.Value
+	// FieldName is the dotted namespace used when reporting errors.
+	FieldName() string
+	// StructFieldName is the Go struct field name, without namespace prefix.
+	StructFieldName() string
+	// Param is the parameter portion of the current rule, e.g. "3" in "min=3".
+	Param() string
+	// Parent is the struct directly containing the field under validation,
+	// used to resolve relative cross-field references such as eqfield.
+	Parent() reflect.Value
+	// Top is the root struct passed to ValidateStruct, used to resolve
+	// absolute cross-field references such as eqcsfield.
+	Top() reflect.Value
+}
+
+// fieldLevel is the concrete FieldLevel implementation used internally.
+type fieldLevel struct {
+	field           reflect.Value
+	fieldName       string
+	structFieldName string
+	param           string
+	parent          reflect.Value
+	top             reflect.Value
+}
+
+func (f *fieldLevel) Field() reflect.Value    { return f.field }
+func (f *fieldLevel) FieldName() string       { return f.fieldName }
+func (f *fieldLevel) StructFieldName() string { return f.structFieldName }
+func (f *fieldLevel) Param() string           { return f.param }
+func (f *fieldLevel) Parent() reflect.Value   { return f.parent }
+func (f *fieldLevel) Top() reflect.Value      { return f.top }
+
+// tagRule is a single parsed rule from a `validate` tag, e.g. {Name: "min", Param: "3"}.
+type tagRule struct {
+	Name  string
+	Param string
+}
+
+// StructValidator is the default Validator implementation. It understands a
+// comma-separated `validate` tag grammar (similar in spirit to
+// go-playground/validator) as well as the legacy `required`, `min`, `max`
+// and `pattern` tags for backward compatibility.
+type StructValidator struct {
+	mu               sync.RWMutex
+	validations      map[string]ValidationFunc
+	customTypeFuncs  map[reflect.Type]CustomTypeFunc
+	structLevelFuncs map[reflect.Type][]StructLevelFunc
+	translations     map[string]translationEntry
+}
+
+// NewValidator creates a StructValidator with the built-in rule set
+// registered.
+func NewValidator() *StructValidator {
+	v := &StructValidator{
+		validations:      make(map[string]ValidationFunc),
+		customTypeFuncs:  make(map[reflect.Type]CustomTypeFunc),
+		structLevelFuncs: make(map[reflect.Type][]StructLevelFunc),
+		translations:     make(map[string]translationEntry),
+	}
+	v.registerBuiltins()
+	v.registerCrossFieldBuiltins()
+
+	return v
+}
+
+// CustomTypeFunc adapts a field of a user-defined (or third-party) type into
+// a plain Go value that the normal rule engine can validate, e.g. unwrapping
+// sql.NullString into a string.
+type CustomTypeFunc func(field reflect.Value) interface{}
+
+// RegisterCustomTypeFunc registers fn to run whenever a field's concrete
+// type matches one of types. The value fn returns (which may be nil) is
+// validated in place of the original field.
+func (v *StructValidator) RegisterCustomTypeFunc(fn CustomTypeFunc, types ...interface{}) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, t := range types {
+		v.customTypeFuncs[reflect.TypeOf(t)] = fn
+	}
+}
+
+func (v *StructValidator) customTypeFor(t reflect.Type) (CustomTypeFunc, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	fn, ok := v.customTypeFuncs[t]
+
+	return fn, ok
+}
+
+// applyCustomType runs any registered CustomTypeFunc for fieldValue's type,
+// returning the extracted value to validate. If no adapter is registered,
+// fieldValue is returned unchanged.
+func (v *StructValidator) applyCustomType(fieldValue reflect.Value) reflect.Value {
+	fn, ok := v.customTypeFor(fieldValue.Type())
+	if !ok {
+		return fieldValue
+	}
+
+	extracted := fn(fieldValue)
+	if extracted == nil {
+		return reflect.Value{}
+	}
+
+	return reflect.ValueOf(extracted)
+}
+
+// RegisterValidation registers a custom validation rule under tag. It
+// overwrites any existing rule (built-in or otherwise) registered under the
+// same tag.
+func (v *StructValidator) RegisterValidation(tag string, fn ValidationFunc) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.validations[tag] = fn
+}
+
+func (v *StructValidator) lookup(tag string) (ValidationFunc, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	fn, ok := v.validations[tag]
+
+	return fn, ok
+}
+
+// ValidateStruct validates config, which must be a struct or a pointer to
+// one, against the `validate` (and legacy) tags found on its fields.
+func (v *StructValidator) ValidateStruct(config any) error {
+	if config == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+
+	value := reflect.ValueOf(config)
+
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return fmt.Errorf("configuration pointer cannot be nil")
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("configuration must be a struct")
+	}
+
+	var errs ValidationErrors
+
+	v.validateStruct(value, "", value, value, &errs)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateStruct walks the fields of value, running tag rules, recursing
+// into nested structs and finally running any struct-level functions
+// registered for value's type. prefix is the dotted namespace of value
+// itself (empty for the root struct); parent is the struct containing
+// value (value itself at the root); top is the root struct passed to
+// ValidateStruct, kept constant across the whole walk so cross-field rules
+// can resolve absolute field references.
+func (v *StructValidator) validateStruct(value reflect.Value, prefix string, parent, top reflect.Value, errs *ValidationErrors) {
+	structType := value.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			// Unexported field.
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		fieldName := v.getFieldName(field, prefix)
+
+		rules, skip := v.buildRules(field)
+		if skip {
+			continue
+		}
+
+		v.validateField(v.applyCustomType(fieldValue), fieldName, field.Name, rules, value, top, errs)
+	}
+
+	v.runStructLevelFuncs(structType, value, prefix, parent, top, errs)
+}
+
+// recurse descends into nested structs (and pointers to structs) so that
+// their fields are validated with a namespaced prefix.
+func (v *StructValidator) recurse(fieldValue reflect.Value, fieldName string, parent, top reflect.Value, errs *ValidationErrors) {
+	switch fieldValue.Kind() {
+	case reflect.Struct:
+		v.validateStruct(fieldValue, fieldName, parent, top, errs)
+	case reflect.Ptr:
+		if !fieldValue.IsNil() && fieldValue.Elem().Kind() == reflect.Struct {
+			v.validateStruct(fieldValue.Elem(), fieldName, parent, top, errs)
+		}
+	}
+}
+
+// buildRules assembles the ordered list of tagRule for field, combining the
+// legacy required/min/max/pattern tags with the `validate` tag grammar. skip
+// is true when the field is marked with the `-` rule and should be ignored
+// entirely.
+func (v *StructValidator) buildRules(field reflect.StructField) (rules []tagRule, skip bool) {
+	if legacyRequired, ok := field.Tag.Lookup("required"); ok && legacyRequired == "true" {
+		rules = append(rules, tagRule{Name: "required"})
+	}
+
+	if legacyMin, ok := field.Tag.Lookup("min"); ok {
+		rules = append(rules, tagRule{Name: "min", Param: legacyMin})
+	}
+
+	if legacyMax, ok := field.Tag.Lookup("max"); ok {
+		rules = append(rules, tagRule{Name: "max", Param: legacyMax})
+	}
+
+	if legacyPattern, ok := field.Tag.Lookup("pattern"); ok {
+		rules = append(rules, tagRule{Name: "pattern", Param: legacyPattern})
+	}
+
+	validateTag, ok := field.Tag.Lookup("validate")
+	if !ok || validateTag == "" {
+		return rules, false
+	}
+
+	if validateTag == "-" {
+		return nil, true
+	}
+
+	rules = append(rules, parseTag(validateTag)...)
+
+	return rules, false
+}
+
+// runRules evaluates rules against fieldValue in order, honouring the
+// `omitempty` meta-rule, and appends a ValidationError for each failing
+// rule.
+func (v *StructValidator) runRules(rules []tagRule, fieldValue reflect.Value, fieldName, structFieldName string, parent, top reflect.Value, errs *ValidationErrors) {
+	for _, rule := range rules {
+		if rule.Name == "omitempty" {
+			if v.isEmpty(fieldValue) {
+				return
+			}
+
+			continue
+		}
+
+		fn, ok := v.lookup(rule.Name)
+		if !ok {
+			continue
+		}
+
+		fl := &fieldLevel{
+			field:           fieldValue,
+			fieldName:       fieldName,
+			structFieldName: structFieldName,
+			param:           rule.Param,
+			parent:          parent,
+			top:             top,
+		}
+
+		if !fn(fl) {
+			*errs = append(*errs, ValidationError{
+				Field: fieldName,
+				Tag:   rule.Name,
+				Param: rule.Param,
+			})
+		}
+	}
+}
+
+// getFieldName resolves the namespace-qualified name used to report errors
+// for field. It prefers the `mapstructure` tag and otherwise lower-cases the
+// Go field name.
+func (v *StructValidator) getFieldName(field reflect.StructField, prefix string) string {
+	name := strings.ToLower(field.Name)
+	if tag, ok := field.Tag.Lookup("mapstructure"); ok && tag != "" {
+		name = tag
+	}
+
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
+
+// isRequired reports whether field carries the legacy required:"true" tag.
+func (v *StructValidator) isRequired(field reflect.StructField) bool {
+	return field.Tag.Get("required") == "true"
+}
+
+// isEmpty reports whether value is empty: invalid, a nil pointer or
+// interface, or a zero-length string, slice, map or array. Values of any
+// other kind (structs, numbers, bools) are never considered empty.
+func (v *StructValidator) isEmpty(value reflect.Value) bool {
+	return isEmptyValue(value)
+}
+
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.String:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len() == 0
+	default:
+		return false
+	}
+}
+
+// parseInt parses the leading (optionally signed) run of digits in s,
+// returning defaultVal if s has none.
+func (v *StructValidator) parseInt(s string, defaultVal int) int {
+	if s == "" {
+		return defaultVal
+	}
+
+	i := 0
+	if s[0] == '-' || s[0] == '+' {
+		i++
+	}
+
+	start := i
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+
+	if i == start {
+		return defaultVal
+	}
+
+	n, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return defaultVal
+	}
+
+	return n
+}
+
+// matchesPattern reports whether input satisfies the named legacy pattern.
+// An empty input always matches, and unknown pattern names are treated as a
+// no-op match.
+func (v *StructValidator) matchesPattern(input, pattern string) bool {
+	return matchesPatternValue(input, pattern)
+}
+
+func matchesPatternValue(input, pattern string) bool {
+	if input == "" {
+		return true
+	}
+
+	switch pattern {
+	case "alphanumeric":
+		return isAlphanumericValue(input)
+	default:
+		return true
+	}
+}
+
+// isAlphanumeric reports whether input consists solely of letters and
+// digits. An empty string is considered alphanumeric.
+func (v *StructValidator) isAlphanumeric(input string) bool {
+	return isAlphanumericValue(input)
+}
+
+func isAlphanumericValue(input string) bool {
+	if input == "" {
+		return true
+	}
+
+	for _, r := range input {
+		if !isLetter(r) && !isDigit(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// parseTag splits a `validate` tag into its ordered rules. Commas separate
+// rules and may be escaped with a backslash to appear literally inside a
+// parameter; the first `=` in a rule separates its name from its parameter.
+func parseTag(tag string) []tagRule {
+	parts := splitEscaped(tag, ',')
+
+	rules := make([]tagRule, 0, len(parts))
+
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+
+		if idx := strings.Index(part, "="); idx >= 0 {
+			rules = append(rules, tagRule{Name: part[:idx], Param: part[idx+1:]})
+		} else {
+			rules = append(rules, tagRule{Name: part})
+		}
+	}
+
+	return rules
+}
+
+// splitEscaped splits s on sep, treating a backslash as an escape for the
+// next rune so that sep (or the backslash itself) can appear literally.
+func splitEscaped(s string, sep rune) []string {
+	var (
+		parts   []string
+		current strings.Builder
+		escaped bool
+	)
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == sep:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	parts = append(parts, current.String())
+
+	return parts
+}
@@ -0,0 +1,184 @@
+package envconfig
+
+import (
+	"reflect"
+	"strings"
+)
+
+// registerCrossFieldBuiltins installs the built-in rules that compare the
+// field under validation against one or more other fields in the same
+// struct graph.
+func (v *StructValidator) registerCrossFieldBuiltins() {
+	v.validations["eqfield"] = crossFieldCompare(func(actual, other reflect.Value) bool {
+		return valuesEqual(actual, other)
+	})
+	v.validations["nefield"] = crossFieldCompare(func(actual, other reflect.Value) bool {
+		return !valuesEqual(actual, other)
+	})
+	v.validations["gtfield"] = crossFieldCompare(func(actual, other reflect.Value) bool {
+		return compareValues(actual, other, func(a, b float64) bool { return a > b })
+	})
+	v.validations["gtefield"] = crossFieldCompare(func(actual, other reflect.Value) bool {
+		return compareValues(actual, other, func(a, b float64) bool { return a >= b })
+	})
+	v.validations["ltfield"] = crossFieldCompare(func(actual, other reflect.Value) bool {
+		return compareValues(actual, other, func(a, b float64) bool { return a < b })
+	})
+	v.validations["ltefield"] = crossFieldCompare(func(actual, other reflect.Value) bool {
+		return compareValues(actual, other, func(a, b float64) bool { return a <= b })
+	})
+	v.validations["eqcsfield"] = func(fl FieldLevel) bool {
+		other, ok := resolveFieldPath(fl.Top(), fl.Param())
+		if !ok {
+			return false
+		}
+
+		return valuesEqual(fl.Field(), other)
+	}
+	v.validations["required_if"] = func(fl FieldLevel) bool {
+		if !conditionsMatch(fl.Parent(), fl.Param()) {
+			return true
+		}
+
+		return !isEmptyValue(fl.Field())
+	}
+	v.validations["required_unless"] = func(fl FieldLevel) bool {
+		if conditionsMatch(fl.Parent(), fl.Param()) {
+			return true
+		}
+
+		return !isEmptyValue(fl.Field())
+	}
+	v.validations["required_with"] = func(fl FieldLevel) bool {
+		if !anyFieldPresent(fl.Parent(), strings.Fields(fl.Param())) {
+			return true
+		}
+
+		return !isEmptyValue(fl.Field())
+	}
+	v.validations["required_without_all"] = func(fl FieldLevel) bool {
+		if anyFieldPresent(fl.Parent(), strings.Fields(fl.Param())) {
+			return true
+		}
+
+		return !isEmptyValue(fl.Field())
+	}
+}
+
+// crossFieldCompare builds a ValidationFunc that resolves its parameter as
+// the name of a sibling field on Parent() and compares it to the field under
+// validation with cmp.
+func crossFieldCompare(cmp func(actual, other reflect.Value) bool) ValidationFunc {
+	return func(fl FieldLevel) bool {
+		other, ok := resolveFieldName(fl.Parent(), fl.Param())
+		if !ok {
+			return false
+		}
+
+		return cmp(fl.Field(), other)
+	}
+}
+
+// resolveFieldName looks up a single, direct field by its Go struct field
+// name on parent.
+func resolveFieldName(parent reflect.Value, name string) (reflect.Value, bool) {
+	if !parent.IsValid() || parent.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	field := parent.FieldByName(name)
+
+	return field, field.IsValid()
+}
+
+// resolveFieldPath walks a dotted path of Go struct field names, starting at
+// root, dereferencing pointers along the way.
+func resolveFieldPath(root reflect.Value, path string) (reflect.Value, bool) {
+	current := root
+
+	for _, segment := range strings.Split(path, ".") {
+		if current.Kind() == reflect.Ptr {
+			if current.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			current = current.Elem()
+		}
+
+		if current.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		current = current.FieldByName(segment)
+		if !current.IsValid() {
+			return reflect.Value{}, false
+		}
+	}
+
+	return current, true
+}
+
+// valuesEqual reports whether two fields hold the same value, comparing by
+// string when both are strings, by size (see sizeOf) when both have one, and
+// with reflect.DeepEqual otherwise.
+func valuesEqual(actual, other reflect.Value) bool {
+	if actual.Kind() == reflect.String && other.Kind() == reflect.String {
+		return actual.String() == other.String()
+	}
+
+	actualSize, ok1 := sizeOf(actual)
+	otherSize, ok2 := sizeOf(other)
+
+	if ok1 && ok2 {
+		return actualSize == otherSize
+	}
+
+	return reflect.DeepEqual(actual.Interface(), other.Interface())
+}
+
+// compareValues compares the sizes (see sizeOf) of two fields using cmp, so
+// strings, slices, maps and arrays are compared by length; fields of a kind
+// without a size (e.g. bools, structs) never satisfy the comparison.
+func compareValues(actual, other reflect.Value, cmp func(a, b float64) bool) bool {
+	actualSize, ok1 := sizeOf(actual)
+	otherSize, ok2 := sizeOf(other)
+
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	return cmp(actualSize, otherSize)
+}
+
+// conditionsMatch parses param as a sequence of "Field Value" pairs and
+// reports whether every referenced field on parent currently holds the
+// given value. Only string fields are compared; a condition on a field of any
+// other kind never matches.
+func conditionsMatch(parent reflect.Value, param string) bool {
+	tokens := strings.Fields(param)
+	if len(tokens) == 0 || len(tokens)%2 != 0 {
+		return false
+	}
+
+	for i := 0; i < len(tokens); i += 2 {
+		field, ok := resolveFieldName(parent, tokens[i])
+		if !ok || field.Kind() != reflect.String || field.String() != tokens[i+1] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// anyFieldPresent reports whether at least one of the named sibling fields
+// on parent is non-empty.
+func anyFieldPresent(parent reflect.Value, names []string) bool {
+	for _, name := range names {
+		field, ok := resolveFieldName(parent, name)
+		if ok && !isEmptyValue(field) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,236 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStructValidator_CrossFieldRules(t *testing.T) {
+	validator := NewValidator()
+
+	type TestStruct struct {
+		Password        string `validate:"required"`
+		ConfirmPassword string `validate:"eqfield=Password"`
+		Low             int    `validate:"ltfield=High"`
+		High            int
+	}
+
+	tests := []struct {
+		name      string
+		config    TestStruct
+		wantError bool
+		errorMsg  string
+	}{
+		{
+			name: "matching confirm password and ordered range",
+			config: TestStruct{
+				Password:        "secret",
+				ConfirmPassword: "secret",
+				Low:             1,
+				High:            10,
+			},
+			wantError: false,
+		},
+		{
+			name: "mismatched confirm password",
+			config: TestStruct{
+				Password:        "secret",
+				ConfirmPassword: "different",
+				Low:             1,
+				High:            10,
+			},
+			wantError: true,
+			errorMsg:  "confirmpassword",
+		},
+		{
+			name: "low not less than high",
+			config: TestStruct{
+				Password:        "secret",
+				ConfirmPassword: "secret",
+				Low:             10,
+				High:            5,
+			},
+			wantError: true,
+			errorMsg:  "low",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validator.ValidateStruct(&testCase.config)
+
+			if testCase.wantError {
+				if err == nil {
+					t.Error("ValidateStruct() expected error but got none")
+					return
+				}
+				if testCase.errorMsg != "" && !strings.Contains(err.Error(), testCase.errorMsg) {
+					t.Errorf("ValidateStruct() error = %v, want to contain %v", err.Error(), testCase.errorMsg)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateStruct() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStructValidator_RequiredIfRules(t *testing.T) {
+	validator := NewValidator()
+
+	type TestStruct struct {
+		Status string
+		Reason string `validate:"required_if=Status blocked"`
+	}
+
+	tests := []struct {
+		name      string
+		config    TestStruct
+		wantError bool
+	}{
+		{
+			name:      "status not blocked, reason optional",
+			config:    TestStruct{Status: "active", Reason: ""},
+			wantError: false,
+		},
+		{
+			name:      "status blocked without reason",
+			config:    TestStruct{Status: "blocked", Reason: ""},
+			wantError: true,
+		},
+		{
+			name:      "status blocked with reason",
+			config:    TestStruct{Status: "blocked", Reason: "maintenance"},
+			wantError: false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validator.ValidateStruct(&testCase.config)
+			if testCase.wantError && err == nil {
+				t.Error("ValidateStruct() expected error but got none")
+			}
+			if !testCase.wantError && err != nil {
+				t.Errorf("ValidateStruct() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStructValidator_RequiredWithRules(t *testing.T) {
+	validator := NewValidator()
+
+	type TestStruct struct {
+		Username string
+		Password string `validate:"required_with=Username"`
+	}
+
+	tests := []struct {
+		name      string
+		config    TestStruct
+		wantError bool
+	}{
+		{
+			name:      "no username, password optional",
+			config:    TestStruct{Username: "", Password: ""},
+			wantError: false,
+		},
+		{
+			name:      "username without password",
+			config:    TestStruct{Username: "alice", Password: ""},
+			wantError: true,
+		},
+		{
+			name:      "username with password",
+			config:    TestStruct{Username: "alice", Password: "secret"},
+			wantError: false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validator.ValidateStruct(&testCase.config)
+			if testCase.wantError && err == nil {
+				t.Error("ValidateStruct() expected error but got none")
+			}
+			if !testCase.wantError && err != nil {
+				t.Errorf("ValidateStruct() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStructValidator_RequiredWithoutAllRules(t *testing.T) {
+	validator := NewValidator()
+
+	type TestStruct struct {
+		Username string
+		Email    string
+		Password string `validate:"required_without_all=Username Email"`
+	}
+
+	tests := []struct {
+		name      string
+		config    TestStruct
+		wantError bool
+	}{
+		{
+			name:      "all alternatives absent, password required",
+			config:    TestStruct{Username: "", Email: "", Password: ""},
+			wantError: true,
+		},
+		{
+			name:      "all alternatives absent, password present",
+			config:    TestStruct{Username: "", Email: "", Password: "secret"},
+			wantError: false,
+		},
+		{
+			name:      "one alternative present, password optional",
+			config:    TestStruct{Username: "alice", Email: "", Password: ""},
+			wantError: false,
+		},
+		{
+			name:      "both alternatives present, password optional",
+			config:    TestStruct{Username: "alice", Email: "alice@example.com", Password: ""},
+			wantError: false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validator.ValidateStruct(&testCase.config)
+			if testCase.wantError && err == nil {
+				t.Error("ValidateStruct() expected error but got none")
+			}
+			if !testCase.wantError && err != nil {
+				t.Errorf("ValidateStruct() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStructValidator_EqCsFieldAbsolutePath(t *testing.T) {
+	validator := NewValidator()
+
+	type Nested struct {
+		Token string
+	}
+
+	type TestStruct struct {
+		Nested  Nested
+		Confirm string `validate:"eqcsfield=Nested.Token"`
+	}
+
+	valid := TestStruct{Nested: Nested{Token: "abc"}, Confirm: "abc"}
+	if err := validator.ValidateStruct(&valid); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	invalid := TestStruct{Nested: Nested{Token: "abc"}, Confirm: "xyz"}
+	err := validator.ValidateStruct(&invalid)
+	if err == nil {
+		t.Error("ValidateStruct() expected error but got none")
+	} else if !strings.Contains(err.Error(), "confirm") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'confirm'", err.Error())
+	}
+}
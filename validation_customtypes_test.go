@@ -0,0 +1,84 @@
+package envconfig
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type walletID struct {
+	value string
+}
+
+func TestStructValidator_RegisterCustomTypeFunc_SQLNullString(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		ns := field.Interface().(sql.NullString)
+		if !ns.Valid {
+			return nil
+		}
+
+		return ns.String
+	}, sql.NullString{})
+
+	type TestStruct struct {
+		Name sql.NullString `validate:"required,min=3"`
+	}
+
+	if err := validator.ValidateStruct(&TestStruct{Name: sql.NullString{String: "abc", Valid: true}}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Name: sql.NullString{Valid: false}})
+	if err == nil {
+		t.Error("ValidateStruct() expected error for invalid NullString but got none")
+	} else if !strings.Contains(err.Error(), "name") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'name'", err.Error())
+	}
+}
+
+func TestStructValidator_RegisterCustomTypeFunc_SQLNullInt64(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		ni := field.Interface().(sql.NullInt64)
+		if !ni.Valid {
+			return nil
+		}
+
+		return ni.Int64
+	}, sql.NullInt64{})
+
+	type TestStruct struct {
+		Count sql.NullInt64 `validate:"required,min=1"`
+	}
+
+	if err := validator.ValidateStruct(&TestStruct{Count: sql.NullInt64{Int64: 5, Valid: true}}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	if err := validator.ValidateStruct(&TestStruct{Count: sql.NullInt64{Valid: false}}); err == nil {
+		t.Error("ValidateStruct() expected error for invalid NullInt64 but got none")
+	}
+}
+
+func TestStructValidator_RegisterCustomTypeFunc_UserWrapper(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return field.Interface().(walletID).value
+	}, walletID{})
+
+	type TestStruct struct {
+		Wallet walletID `validate:"required,uuid"`
+	}
+
+	valid := walletID{value: "123e4567-e89b-12d3-a456-426614174000"}
+	if err := validator.ValidateStruct(&TestStruct{Wallet: valid}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	invalid := walletID{value: "not-a-uuid"}
+	if err := validator.ValidateStruct(&TestStruct{Wallet: invalid}); err == nil {
+		t.Error("ValidateStruct() expected error for invalid uuid but got none")
+	}
+}
@@ -0,0 +1,80 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// validateField runs rules against fieldValue, splitting off a `dive`
+// directive (if present) so the rules before it apply to the container
+// itself and the rules after it apply to each element. Fields without a
+// `dive` directive are validated and recursed into exactly as before.
+func (v *StructValidator) validateField(fieldValue reflect.Value, fieldName, structFieldName string, rules []tagRule, parent, top reflect.Value, errs *ValidationErrors) {
+	diveIdx := -1
+
+	for i, rule := range rules {
+		if rule.Name == "dive" {
+			diveIdx = i
+			break
+		}
+	}
+
+	if diveIdx == -1 {
+		v.runRules(rules, fieldValue, fieldName, structFieldName, parent, top, errs)
+		v.recurse(fieldValue, fieldName, parent, top, errs)
+
+		return
+	}
+
+	v.runRules(rules[:diveIdx], fieldValue, fieldName, structFieldName, parent, top, errs)
+	v.dive(fieldValue, fieldName, structFieldName, rules[diveIdx+1:], parent, top, errs)
+}
+
+// dive applies elementRules to each element of a slice, array or map,
+// namespacing errors as "field[index]" (or "field[key]" for maps), and
+// recurses into struct elements. Multi-dimensional containers work because
+// elementRules may itself contain another `dive`, which validateField will
+// split again.
+func (v *StructValidator) dive(fieldValue reflect.Value, fieldName, structFieldName string, elementRules []tagRule, parent, top reflect.Value, errs *ValidationErrors) {
+	switch fieldValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < fieldValue.Len(); i++ {
+			elem := fieldValue.Index(i)
+			elemName := fmt.Sprintf("%s[%d]", fieldName, i)
+			elemStructName := fmt.Sprintf("%s[%d]", structFieldName, i)
+
+			v.validateField(elem, elemName, elemStructName, elementRules, parent, top, errs)
+		}
+	case reflect.Map:
+		keyRules, valueRules := splitKeysEndkeys(elementRules)
+
+		for _, key := range fieldValue.MapKeys() {
+			val := fieldValue.MapIndex(key)
+			elemName := fmt.Sprintf("%s[%v]", fieldName, key.Interface())
+			elemStructName := fmt.Sprintf("%s[%v]", structFieldName, key.Interface())
+
+			if keyRules != nil {
+				v.runRules(keyRules, key, elemName, elemStructName, parent, top, errs)
+			}
+
+			v.validateField(val, elemName, elemStructName, valueRules, parent, top, errs)
+		}
+	}
+}
+
+// splitKeysEndkeys separates a `keys ... endkeys value-rules` element rule
+// list into the key rules and the value rules. Without a leading `keys`
+// token, every rule applies to the map's values.
+func splitKeysEndkeys(rules []tagRule) (keyRules, valueRules []tagRule) {
+	if len(rules) == 0 || rules[0].Name != "keys" {
+		return nil, rules
+	}
+
+	for i := 1; i < len(rules); i++ {
+		if rules[i].Name == "endkeys" {
+			return rules[1:i], rules[i+1:]
+		}
+	}
+
+	return rules[1:], nil
+}
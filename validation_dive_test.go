@@ -0,0 +1,143 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStructValidator_DiveSlice(t *testing.T) {
+	validator := NewValidator()
+
+	type Item struct {
+		Name string `validate:"required,min=3"`
+	}
+
+	type TestStruct struct {
+		Tags  []string `validate:"min=1,dive,required,min=3"`
+		Items []Item   `validate:"dive"`
+	}
+
+	tests := []struct {
+		name      string
+		config    TestStruct
+		wantError bool
+		errorMsg  string
+	}{
+		{
+			name: "valid tags and items",
+			config: TestStruct{
+				Tags:  []string{"abc", "defg"},
+				Items: []Item{{Name: "widget"}},
+			},
+			wantError: false,
+		},
+		{
+			name: "empty tags container fails min",
+			config: TestStruct{
+				Tags:  []string{},
+				Items: []Item{{Name: "widget"}},
+			},
+			wantError: true,
+			errorMsg:  "tags",
+		},
+		{
+			name: "one tag too short",
+			config: TestStruct{
+				Tags:  []string{"abc", "de"},
+				Items: []Item{{Name: "widget"}},
+			},
+			wantError: true,
+			errorMsg:  "tags[1]",
+		},
+		{
+			name: "nested item invalid",
+			config: TestStruct{
+				Tags:  []string{"abc"},
+				Items: []Item{{Name: "ab"}},
+			},
+			wantError: true,
+			errorMsg:  "items[0].name",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validator.ValidateStruct(&testCase.config)
+
+			if testCase.wantError {
+				if err == nil {
+					t.Error("ValidateStruct() expected error but got none")
+					return
+				}
+				if testCase.errorMsg != "" && !strings.Contains(err.Error(), testCase.errorMsg) {
+					t.Errorf("ValidateStruct() error = %v, want to contain %v", err.Error(), testCase.errorMsg)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateStruct() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStructValidator_DiveMapKeysAndValues(t *testing.T) {
+	validator := NewValidator()
+
+	type TestStruct struct {
+		Labels map[string]string `validate:"dive,keys,alphanumeric,endkeys,required"`
+	}
+
+	tests := []struct {
+		name      string
+		config    TestStruct
+		wantError bool
+	}{
+		{
+			name:      "valid map",
+			config:    TestStruct{Labels: map[string]string{"env": "prod"}},
+			wantError: false,
+		},
+		{
+			name:      "invalid key",
+			config:    TestStruct{Labels: map[string]string{"env-1": "prod"}},
+			wantError: true,
+		},
+		{
+			name:      "missing value",
+			config:    TestStruct{Labels: map[string]string{"env": ""}},
+			wantError: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validator.ValidateStruct(&testCase.config)
+			if testCase.wantError && err == nil {
+				t.Error("ValidateStruct() expected error but got none")
+			}
+			if !testCase.wantError && err != nil {
+				t.Errorf("ValidateStruct() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStructValidator_DiveMultiDimensional(t *testing.T) {
+	validator := NewValidator()
+
+	type TestStruct struct {
+		Grid [][]string `validate:"dive,dive,required,min=2"`
+	}
+
+	valid := TestStruct{Grid: [][]string{{"ab", "cd"}, {"ef"}}}
+	if err := validator.ValidateStruct(&valid); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	invalid := TestStruct{Grid: [][]string{{"ab", "c"}}}
+	err := validator.ValidateStruct(&invalid)
+	if err == nil {
+		t.Error("ValidateStruct() expected error but got none")
+	} else if !strings.Contains(err.Error(), "grid[0][1]") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'grid[0][1]'", err.Error())
+	}
+}
@@ -0,0 +1,228 @@
+package envconfig
+
+import (
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	alphaRegexp    = regexp.MustCompile(`^[a-zA-Z]*$`)
+	numericRegexp  = regexp.MustCompile(`^[0-9]*$`)
+	uuidRegexp     = regexp.MustCompile(`^(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	hostnameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+	// emailRegexp matches a bare address only (local-part@domain); unlike
+	// net/mail.ParseAddress it rejects RFC 5322 mailbox syntax such as a
+	// display name ("Name <addr>"), which `validate:"email"` must not accept.
+	emailRegexp = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)+$`)
+)
+
+// registerBuiltins installs the built-in validation rules understood by the
+// `validate` tag grammar.
+func (v *StructValidator) registerBuiltins() {
+	v.validations["required"] = validateRequired
+	v.validations["len"] = validateLen
+	v.validations["min"] = validateMin
+	v.validations["max"] = validateMax
+	v.validations["gt"] = validateGt
+	v.validations["gte"] = validateGte
+	v.validations["lt"] = validateLt
+	v.validations["lte"] = validateLte
+	v.validations["eq"] = validateEq
+	v.validations["ne"] = validateNe
+	v.validations["oneof"] = validateOneof
+	v.validations["regex"] = validateRegex
+	v.validations["email"] = validateEmail
+	v.validations["url"] = validateURL
+	v.validations["uuid"] = validateUUID
+	v.validations["ip"] = validateIP
+	v.validations["cidr"] = validateCIDR
+	v.validations["hostname"] = validateHostname
+	v.validations["alphanumeric"] = validateAlphanumeric
+	v.validations["alpha"] = validateAlpha
+	v.validations["numeric"] = validateNumeric
+	v.validations["pattern"] = validateLegacyPattern
+}
+
+func validateRequired(fl FieldLevel) bool {
+	return !isEmptyValue(fl.Field())
+}
+
+// sizeOf returns the "size" of value for len/min/max/gt/.../lte style rules:
+// the length for strings, slices, maps and arrays, and the numeric value
+// (as a float64) for integer, unsigned and float kinds. ok is false for any
+// other kind.
+func sizeOf(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(len([]rune(value.String()))), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(value.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func paramFloat(param string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(param), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return f, true
+}
+
+func compareSize(fl FieldLevel, cmp func(actual, want float64) bool) bool {
+	actual, ok := sizeOf(fl.Field())
+	if !ok {
+		return false
+	}
+
+	want, ok := paramFloat(fl.Param())
+	if !ok {
+		return false
+	}
+
+	return cmp(actual, want)
+}
+
+func validateLen(fl FieldLevel) bool {
+	return compareSize(fl, func(actual, want float64) bool { return actual == want })
+}
+
+func validateMin(fl FieldLevel) bool {
+	return compareSize(fl, func(actual, want float64) bool { return actual >= want })
+}
+
+func validateMax(fl FieldLevel) bool {
+	return compareSize(fl, func(actual, want float64) bool { return actual <= want })
+}
+
+func validateGt(fl FieldLevel) bool {
+	return compareSize(fl, func(actual, want float64) bool { return actual > want })
+}
+
+func validateGte(fl FieldLevel) bool {
+	return compareSize(fl, func(actual, want float64) bool { return actual >= want })
+}
+
+func validateLt(fl FieldLevel) bool {
+	return compareSize(fl, func(actual, want float64) bool { return actual < want })
+}
+
+func validateLte(fl FieldLevel) bool {
+	return compareSize(fl, func(actual, want float64) bool { return actual <= want })
+}
+
+func validateEq(fl FieldLevel) bool {
+	value := fl.Field()
+	if value.Kind() == reflect.String {
+		return value.String() == fl.Param()
+	}
+
+	return compareSize(fl, func(actual, want float64) bool { return actual == want })
+}
+
+func validateNe(fl FieldLevel) bool {
+	return !validateEq(fl)
+}
+
+func validateOneof(fl FieldLevel) bool {
+	options := strings.Fields(fl.Param())
+
+	value := fl.Field()
+
+	var actual string
+
+	switch value.Kind() {
+	case reflect.String:
+		actual = value.String()
+	default:
+		if size, ok := sizeOf(value); ok {
+			actual = strconv.FormatFloat(size, 'f', -1, 64)
+		}
+	}
+
+	for _, option := range options {
+		if option == actual {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateRegex(fl FieldLevel) bool {
+	re, err := regexp.Compile(fl.Param())
+	if err != nil {
+		return false
+	}
+
+	return re.MatchString(stringValue(fl.Field()))
+}
+
+func validateEmail(fl FieldLevel) bool {
+	return emailRegexp.MatchString(stringValue(fl.Field()))
+}
+
+func validateURL(fl FieldLevel) bool {
+	s := stringValue(fl.Field())
+
+	u, err := url.Parse(s)
+
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+func validateUUID(fl FieldLevel) bool {
+	return uuidRegexp.MatchString(stringValue(fl.Field()))
+}
+
+func validateIP(fl FieldLevel) bool {
+	return net.ParseIP(stringValue(fl.Field())) != nil
+}
+
+func validateCIDR(fl FieldLevel) bool {
+	_, _, err := net.ParseCIDR(stringValue(fl.Field()))
+
+	return err == nil
+}
+
+func validateHostname(fl FieldLevel) bool {
+	return hostnameRegexp.MatchString(stringValue(fl.Field()))
+}
+
+func validateAlphanumeric(fl FieldLevel) bool {
+	return isAlphanumericValue(stringValue(fl.Field()))
+}
+
+func validateAlpha(fl FieldLevel) bool {
+	return alphaRegexp.MatchString(stringValue(fl.Field()))
+}
+
+func validateNumeric(fl FieldLevel) bool {
+	return numericRegexp.MatchString(stringValue(fl.Field()))
+}
+
+// validateLegacyPattern implements the original, pre-`validate`-tag
+// pattern:"..." behaviour: only the named "alphanumeric" pattern is
+// understood, and anything else (including an empty value) passes.
+func validateLegacyPattern(fl FieldLevel) bool {
+	return matchesPatternValue(stringValue(fl.Field()), fl.Param())
+}
+
+func stringValue(value reflect.Value) string {
+	if value.Kind() == reflect.String {
+		return value.String()
+	}
+
+	return ""
+}
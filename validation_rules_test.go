@@ -0,0 +1,253 @@
+package envconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStructValidator_ValidateTag(t *testing.T) {
+	validator := NewValidator()
+
+	type TestStruct struct {
+		Name        string `validate:"required,min=3,max=32"`
+		Environment string `validate:"oneof=dev staging prod"`
+		Email       string `validate:"omitempty,email"`
+		Count       int    `validate:"gte=1,lte=10"`
+	}
+
+	tests := []struct {
+		name      string
+		config    TestStruct
+		wantError bool
+		errorMsg  string
+	}{
+		{
+			name: "valid struct",
+			config: TestStruct{
+				Name:        "service",
+				Environment: "staging",
+				Email:       "",
+				Count:       5,
+			},
+			wantError: false,
+		},
+		{
+			name: "name too short",
+			config: TestStruct{
+				Name:        "ab",
+				Environment: "dev",
+				Count:       1,
+			},
+			wantError: true,
+			errorMsg:  "name",
+		},
+		{
+			name: "environment not in oneof",
+			config: TestStruct{
+				Name:        "service",
+				Environment: "test",
+				Count:       1,
+			},
+			wantError: true,
+			errorMsg:  "environment",
+		},
+		{
+			name: "omitempty skips email check when blank",
+			config: TestStruct{
+				Name:        "service",
+				Environment: "dev",
+				Email:       "",
+				Count:       1,
+			},
+			wantError: false,
+		},
+		{
+			name: "invalid email when present",
+			config: TestStruct{
+				Name:        "service",
+				Environment: "dev",
+				Email:       "not-an-email",
+				Count:       1,
+			},
+			wantError: true,
+			errorMsg:  "email",
+		},
+		{
+			name: "email rejects RFC 5322 mailbox syntax with a display name",
+			config: TestStruct{
+				Name:        "service",
+				Environment: "dev",
+				Email:       "Administrator <admin@example.com>",
+				Count:       1,
+			},
+			wantError: true,
+			errorMsg:  "email",
+		},
+		{
+			name: "count out of range",
+			config: TestStruct{
+				Name:        "service",
+				Environment: "dev",
+				Count:       20,
+			},
+			wantError: true,
+			errorMsg:  "count",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validator.ValidateStruct(&testCase.config)
+
+			if testCase.wantError {
+				if err == nil {
+					t.Error("ValidateStruct() expected error but got none")
+					return
+				}
+				if testCase.errorMsg != "" && !strings.Contains(err.Error(), testCase.errorMsg) {
+					t.Errorf("ValidateStruct() error = %v, want to contain %v", err.Error(), testCase.errorMsg)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateStruct() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStructValidator_ValidateTagSkip(t *testing.T) {
+	validator := NewValidator()
+
+	type TestStruct struct {
+		Ignored string `validate:"-" required:"true"`
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Ignored: ""})
+	if err != nil {
+		t.Errorf("ValidateStruct() unexpected error for '-' tag = %v", err)
+	}
+}
+
+func TestStructValidator_RegisterValidation(t *testing.T) {
+	validator := NewValidator()
+
+	validator.RegisterValidation("even", func(fl FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	})
+
+	type TestStruct struct {
+		Value int `validate:"even"`
+	}
+
+	if err := validator.ValidateStruct(&TestStruct{Value: 3}); err == nil {
+		t.Error("ValidateStruct() expected error for odd value")
+	}
+
+	if err := validator.ValidateStruct(&TestStruct{Value: 4}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error for even value = %v", err)
+	}
+}
+
+func TestStructValidator_BuiltinRules(t *testing.T) {
+	validator := NewValidator()
+
+	tests := []struct {
+		name      string
+		validate  string
+		value     string
+		wantError bool
+	}{
+		{name: "len exact match", validate: "len=4", value: "abcd", wantError: false},
+		{name: "len mismatch", validate: "len=4", value: "abc", wantError: true},
+		{name: "gt satisfied", validate: "gt=3", value: "abcd", wantError: false},
+		{name: "gt not satisfied by equal length", validate: "gt=4", value: "abcd", wantError: true},
+		{name: "lt satisfied", validate: "lt=4", value: "abc", wantError: false},
+		{name: "lt not satisfied by equal length", validate: "lt=3", value: "abc", wantError: true},
+		{name: "eq satisfied", validate: "eq=hello", value: "hello", wantError: false},
+		{name: "eq not satisfied", validate: "eq=hello", value: "world", wantError: true},
+		{name: "ne satisfied", validate: "ne=hello", value: "world", wantError: false},
+		{name: "ne not satisfied", validate: "ne=hello", value: "hello", wantError: true},
+		{name: "regex satisfied", validate: `regex=^[a-z]+$`, value: "abc", wantError: false},
+		{name: "regex not satisfied", validate: `regex=^[a-z]+$`, value: "ABC", wantError: true},
+		{name: "url satisfied", validate: "url", value: "https://example.com/path", wantError: false},
+		{name: "url not satisfied", validate: "url", value: "not a url", wantError: true},
+		{name: "ip satisfied", validate: "ip", value: "192.168.1.1", wantError: false},
+		{name: "ip not satisfied", validate: "ip", value: "not-an-ip", wantError: true},
+		{name: "cidr satisfied", validate: "cidr", value: "192.168.1.0/24", wantError: false},
+		{name: "cidr not satisfied", validate: "cidr", value: "192.168.1.0", wantError: true},
+		{name: "hostname satisfied", validate: "hostname", value: "example.com", wantError: false},
+		{name: "hostname not satisfied", validate: "hostname", value: "not a hostname!", wantError: true},
+		{name: "alpha satisfied", validate: "alpha", value: "abcXYZ", wantError: false},
+		{name: "alpha not satisfied", validate: "alpha", value: "abc123", wantError: true},
+		{name: "numeric satisfied", validate: "numeric", value: "12345", wantError: false},
+		{name: "numeric not satisfied", validate: "numeric", value: "12a45", wantError: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			name := testCase.validate
+			param := ""
+			if idx := strings.Index(testCase.validate, "="); idx >= 0 {
+				name = testCase.validate[:idx]
+				param = testCase.validate[idx+1:]
+			}
+
+			fn, ok := validator.lookup(name)
+			if !ok {
+				t.Fatalf("builtin rule %q is not registered", name)
+			}
+
+			fl := &fieldLevel{
+				field:     reflect.ValueOf(testCase.value),
+				fieldName: "value",
+				param:     param,
+			}
+
+			result := fn(fl)
+			if testCase.wantError && result {
+				t.Errorf("rule %q matched value %q, want failure", testCase.validate, testCase.value)
+			}
+			if !testCase.wantError && !result {
+				t.Errorf("rule %q rejected value %q, want success", testCase.validate, testCase.value)
+			}
+		})
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected []tagRule
+	}{
+		{
+			name:     "simple rules",
+			tag:      "required,min=3,max=32",
+			expected: []tagRule{{Name: "required"}, {Name: "min", Param: "3"}, {Name: "max", Param: "32"}},
+		},
+		{
+			name:     "escaped comma in param",
+			tag:      `oneof=a\,b c`,
+			expected: []tagRule{{Name: "oneof", Param: "a,b c"}},
+		},
+		{
+			name:     "empty tag",
+			tag:      "",
+			expected: []tagRule{},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := parseTag(testCase.tag)
+			if len(result) != len(testCase.expected) {
+				t.Fatalf("parseTag() = %v, want %v", result, testCase.expected)
+			}
+			for i := range result {
+				if result[i] != testCase.expected[i] {
+					t.Errorf("parseTag()[%d] = %v, want %v", i, result[i], testCase.expected[i])
+				}
+			}
+		})
+	}
+}
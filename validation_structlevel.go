@@ -0,0 +1,81 @@
+package envconfig
+
+import (
+	"reflect"
+	"strings"
+)
+
+// StructLevelFunc validates an entire struct with arbitrary Go code, for
+// rules that span several fields and can't be expressed with tags alone.
+type StructLevelFunc func(sl StructLevel)
+
+// StructLevel gives a StructLevelFunc access to the struct under
+// validation, its parent and the root of the validation, plus a way to
+// report failures using the same dotted-namespace convention as tag rules.
+type StructLevel interface {
+	// Current is the struct value the StructLevelFunc was registered against.
+	Current() reflect.Value
+	// Parent is the struct containing Current (Current itself at the root).
+	Parent() reflect.Value
+	// Top is the root struct passed to ValidateStruct.
+	Top() reflect.Value
+	// ReportError appends a validation failure for one of Current's fields.
+	// field is the offending field's value (informational only); fieldName
+	// is used, lower-cased and namespaced, as the reported Field; tag and
+	// param describe the violated rule for the error message.
+	ReportError(field interface{}, fieldName, structFieldName, tag, param string)
+}
+
+// RegisterStructValidation registers fn to run after per-field tag
+// validation completes for every struct whose type matches one of types.
+// Multiple functions may be registered against the same type; they run in
+// registration order.
+func (v *StructValidator) RegisterStructValidation(fn StructLevelFunc, types ...interface{}) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, t := range types {
+		rt := reflect.TypeOf(t)
+		v.structLevelFuncs[rt] = append(v.structLevelFuncs[rt], fn)
+	}
+}
+
+// runStructLevelFuncs invokes any StructLevelFunc registered for structType,
+// appending their reported errors to errs.
+func (v *StructValidator) runStructLevelFuncs(structType reflect.Type, value reflect.Value, namespace string, parent, top reflect.Value, errs *ValidationErrors) {
+	v.mu.RLock()
+	fns := append([]StructLevelFunc(nil), v.structLevelFuncs[structType]...)
+	v.mu.RUnlock()
+
+	for _, fn := range fns {
+		fn(&structLevel{
+			current:   value,
+			parent:    parent,
+			top:       top,
+			namespace: namespace,
+			errs:      errs,
+		})
+	}
+}
+
+// structLevel is the concrete StructLevel implementation used internally.
+type structLevel struct {
+	current   reflect.Value
+	parent    reflect.Value
+	top       reflect.Value
+	namespace string
+	errs      *ValidationErrors
+}
+
+func (s *structLevel) Current() reflect.Value { return s.current }
+func (s *structLevel) Parent() reflect.Value  { return s.parent }
+func (s *structLevel) Top() reflect.Value     { return s.top }
+
+func (s *structLevel) ReportError(_ interface{}, fieldName, _, tag, param string) {
+	name := strings.ToLower(fieldName)
+	if s.namespace != "" {
+		name = s.namespace + "." + name
+	}
+
+	*s.errs = append(*s.errs, ValidationError{Field: name, Tag: tag, Param: param})
+}
@@ -0,0 +1,94 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+type authConfig struct {
+	APIKey     string
+	OAuthToken string
+}
+
+func validateAuthConfig(sl StructLevel) {
+	cfg := sl.Current().Interface().(authConfig)
+
+	if cfg.APIKey == "" && cfg.OAuthToken == "" {
+		sl.ReportError(cfg.APIKey, "APIKey", "APIKey", "required_without", "OAuthToken")
+	}
+
+	if cfg.APIKey != "" && cfg.OAuthToken != "" {
+		sl.ReportError(cfg.OAuthToken, "OAuthToken", "OAuthToken", "excluded_with", "APIKey")
+	}
+}
+
+func TestStructValidator_RegisterStructValidation(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterStructValidation(validateAuthConfig, authConfig{})
+
+	tests := []struct {
+		name      string
+		config    authConfig
+		wantError bool
+		errorMsg  string
+	}{
+		{
+			name:      "only api key",
+			config:    authConfig{APIKey: "abc"},
+			wantError: false,
+		},
+		{
+			name:      "only oauth token",
+			config:    authConfig{OAuthToken: "xyz"},
+			wantError: false,
+		},
+		{
+			name:      "neither set",
+			config:    authConfig{},
+			wantError: true,
+			errorMsg:  "apikey",
+		},
+		{
+			name:      "both set",
+			config:    authConfig{APIKey: "abc", OAuthToken: "xyz"},
+			wantError: true,
+			errorMsg:  "oauthtoken",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validator.ValidateStruct(&testCase.config)
+
+			if testCase.wantError {
+				if err == nil {
+					t.Error("ValidateStruct() expected error but got none")
+					return
+				}
+				if !strings.Contains(err.Error(), testCase.errorMsg) {
+					t.Errorf("ValidateStruct() error = %v, want to contain %v", err.Error(), testCase.errorMsg)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateStruct() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStructValidator_StructLevel_NestedNamespace(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterStructValidation(validateAuthConfig, authConfig{})
+
+	type Wrapper struct {
+		Auth authConfig
+	}
+
+	err := validator.ValidateStruct(&Wrapper{Auth: authConfig{}})
+	if err == nil {
+		t.Fatal("ValidateStruct() expected error but got none")
+	}
+
+	if !strings.Contains(err.Error(), "auth.apikey") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'auth.apikey'", err.Error())
+	}
+}
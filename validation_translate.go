@@ -0,0 +1,90 @@
+package envconfig
+
+import "fmt"
+
+// Translator renders a ValidationError as a human-readable message. It is
+// the extension point for localizing or otherwise customizing validation
+// error text.
+type Translator interface {
+	Translate(ve ValidationError) string
+}
+
+// RegisterTranslationsFunc is run once, at registration time, to let a
+// Translator prepare itself (e.g. load message templates for a tag) before
+// it is used to translate errors.
+type RegisterTranslationsFunc func(trans Translator) error
+
+// TranslationFunc renders ve using trans, the Translator that was
+// registered alongside it.
+type TranslationFunc func(trans Translator, ve ValidationError) string
+
+// EnglishTranslator is the default Translator (the initial DefaultTranslator),
+// used by ValidationError.Error when no explicit Message is set and by
+// StructValidator.Translate for tags without a registered translation.
+// Translations registered on a StructValidator do not affect Error.
+type EnglishTranslator struct{}
+
+// Translate implements Translator.
+func (EnglishTranslator) Translate(ve ValidationError) string {
+	switch ve.Tag {
+	case "":
+		return ""
+	case "required":
+		return "this field is required"
+	case "min":
+		return "minimum length"
+	case "max":
+		return "maximum length"
+	case "pattern":
+		return "does not match required pattern"
+	default:
+		if ve.Param != "" {
+			return fmt.Sprintf("failed on the '%s=%s' tag", ve.Tag, ve.Param)
+		}
+
+		return fmt.Sprintf("failed on the '%s' tag", ve.Tag)
+	}
+}
+
+// DefaultTranslator is used by ValidationError.Error when no explicit
+// Message was set.
+var DefaultTranslator Translator = EnglishTranslator{}
+
+type translationEntry struct {
+	translator Translator
+	translate  TranslationFunc
+}
+
+// RegisterTranslation overrides the message produced for errors whose Tag
+// is tag. registerFn runs once against translator to let it prepare itself
+// (e.g. load per-locale templates); translateFn is invoked with translator
+// for every subsequent error with this Tag.
+func (v *StructValidator) RegisterTranslation(tag string, translator Translator, registerFn RegisterTranslationsFunc, translateFn TranslationFunc) error {
+	if registerFn != nil {
+		if err := registerFn(translator); err != nil {
+			return err
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.translations[tag] = translationEntry{translator: translator, translate: translateFn}
+
+	return nil
+}
+
+// Translate implements Translator, using any translation registered via
+// RegisterTranslation for ve.Tag and falling back to DefaultTranslator
+// otherwise.
+func (v *StructValidator) Translate(ve ValidationError) string {
+	v.mu.RLock()
+	entry, ok := v.translations[ve.Tag]
+	v.mu.RUnlock()
+
+	if ok {
+		return entry.translate(entry.translator, ve)
+	}
+
+	return DefaultTranslator.Translate(ve)
+}
@@ -0,0 +1,72 @@
+package envconfig
+
+import "testing"
+
+type frenchTranslator struct {
+	messages map[string]string
+}
+
+func (f *frenchTranslator) Translate(ve ValidationError) string {
+	if msg, ok := f.messages[ve.Tag]; ok {
+		return msg
+	}
+
+	return EnglishTranslator{}.Translate(ve)
+}
+
+func TestStructValidator_RegisterTranslation(t *testing.T) {
+	validator := NewValidator()
+
+	fr := &frenchTranslator{messages: map[string]string{}}
+
+	err := validator.RegisterTranslation("required", fr,
+		func(trans Translator) error {
+			fr.messages["required"] = "ce champ est obligatoire"
+
+			return nil
+		},
+		func(trans Translator, ve ValidationError) string {
+			return trans.Translate(ve)
+		},
+	)
+	if err != nil {
+		t.Fatalf("RegisterTranslation() unexpected error = %v", err)
+	}
+
+	type TestStruct struct {
+		Name string `validate:"required"`
+	}
+
+	validateErr := validator.ValidateStruct(&TestStruct{})
+	if validateErr == nil {
+		t.Fatal("ValidateStruct() expected error but got none")
+	}
+
+	errs, ok := validateErr.(ValidationErrors)
+	if !ok {
+		t.Fatalf("ValidateStruct() error type = %T, want ValidationErrors", validateErr)
+	}
+
+	messages := errs.Translate(validator)
+	if messages["name"] != "ce champ est obligatoire" {
+		t.Errorf("Translate()[\"name\"] = %q, want %q", messages["name"], "ce champ est obligatoire")
+	}
+}
+
+func TestValidationError_ErrorUsesDefaultTranslatorWhenMessageEmpty(t *testing.T) {
+	err := ValidationError{Field: "count", Tag: "min"}
+
+	expected := "validation error for field 'count': minimum length"
+	if err.Error() != expected {
+		t.Errorf("Error() = %v, want %v", err.Error(), expected)
+	}
+}
+
+func TestValidationError_ErrorPrefersExplicitMessage(t *testing.T) {
+	err := ValidationError{Field: "count", Tag: "min", Message: "explicit message"}
+
+	expected := "validation error for field 'count': explicit message"
+	if err.Error() != expected {
+		t.Errorf("Error() = %v, want %v", err.Error(), expected)
+	}
+}